@@ -0,0 +1,123 @@
+package gorsa
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+// PKCSFormat selects the PEM encoding used by MarshalPrivateKeyPEM.
+type PKCSFormat int
+
+const (
+	// FormatPKCS1 writes an "RSA PRIVATE KEY" block (unencrypted only).
+	FormatPKCS1 PKCSFormat = iota
+	// FormatPKCS8 writes a "PRIVATE KEY" block, or "ENCRYPTED PRIVATE KEY"
+	// when a password is supplied.
+	FormatPKCS8
+)
+
+// PublicKeyFormat selects the PEM encoding used by MarshalPublicKeyPEM.
+type PublicKeyFormat int
+
+const (
+	// FormatPKIX writes a "PUBLIC KEY" block (the modern default).
+	FormatPKIX PublicKeyFormat = iota
+	// FormatPKCS1Public writes an "RSA PUBLIC KEY" block.
+	FormatPKCS1Public
+)
+
+// GenerateKeyPair creates a new RSA private key of the given bit size.
+func GenerateKeyPair(bits int) (*rsa.PrivateKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return nil, fmt.Errorf("gorsa: generating key pair: %w", err)
+	}
+	return key, nil
+}
+
+// MarshalPrivateKeyPEM encodes key as a PEM block in the requested format. If
+// password is non-empty the key is written as PKCS#8 "ENCRYPTED PRIVATE KEY"
+// using PBES2 (AES-256-CBC + PBKDF2-SHA256) regardless of format, since
+// PKCS#1 has no standard encrypted form and the deprecated PBES1
+// x509.EncryptPEMBlock path is not used here.
+func MarshalPrivateKeyPEM(key *rsa.PrivateKey, format PKCSFormat, password string) ([]byte, error) {
+	if password != "" {
+		der, err := MarshalPKCS8EncryptedPrivateKey(key, []byte(password))
+		if err != nil {
+			return nil, err
+		}
+		return pem.EncodeToMemory(&pem.Block{
+			Type:  "ENCRYPTED PRIVATE KEY",
+			Bytes: der,
+		}), nil
+	}
+
+	switch format {
+	case FormatPKCS1:
+		return pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}), nil
+	case FormatPKCS8:
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("gorsa: marshaling PKCS#8 private key: %w", err)
+		}
+		return pem.EncodeToMemory(&pem.Block{
+			Type:  "PRIVATE KEY",
+			Bytes: der,
+		}), nil
+	default:
+		return nil, fmt.Errorf("gorsa: unsupported PKCSFormat %d", format)
+	}
+}
+
+// MarshalPublicKeyPEM encodes pub as a PEM block in the requested format.
+func MarshalPublicKeyPEM(pub *rsa.PublicKey, format PublicKeyFormat) ([]byte, error) {
+	switch format {
+	case FormatPKIX:
+		der, err := x509.MarshalPKIXPublicKey(pub)
+		if err != nil {
+			return nil, fmt.Errorf("gorsa: marshaling PKIX public key: %w", err)
+		}
+		return pem.EncodeToMemory(&pem.Block{
+			Type:  "PUBLIC KEY",
+			Bytes: der,
+		}), nil
+	case FormatPKCS1Public:
+		return pem.EncodeToMemory(&pem.Block{
+			Type:  "RSA PUBLIC KEY",
+			Bytes: x509.MarshalPKCS1PublicKey(pub),
+		}), nil
+	default:
+		return nil, fmt.Errorf("gorsa: unsupported PublicKeyFormat %d", format)
+	}
+}
+
+// WriteKeyPair generates PEM-encoded files for priv and its public half at
+// privPath and pubPath, creating them with the given permissions. If
+// password is non-empty the private key is written as an encrypted PKCS#8
+// block (see MarshalPrivateKeyPEM).
+func WriteKeyPair(priv *rsa.PrivateKey, privPath, pubPath string, perm os.FileMode, password string) error {
+	privPEM, err := MarshalPrivateKeyPEM(priv, FormatPKCS8, password)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(privPath, privPEM, perm); err != nil {
+		return fmt.Errorf("gorsa: writing private key to %s: %w", privPath, err)
+	}
+
+	pubPEM, err := MarshalPublicKeyPEM(&priv.PublicKey, FormatPKIX)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(pubPath, pubPEM, perm); err != nil {
+		return fmt.Errorf("gorsa: writing public key to %s: %w", pubPath, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package gorsa
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+)
+
+// jwk is the subset of RFC 7517 fields gorsa reads and writes for RSA keys.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// Private key fields (RFC 7518 section 6.3.2), omitted for public JWKs.
+	D  string `json:"d,omitempty"`
+	P  string `json:"p,omitempty"`
+	Q  string `json:"q,omitempty"`
+	Dp string `json:"dp,omitempty"`
+	Dq string `json:"dq,omitempty"`
+	Qi string `json:"qi,omitempty"`
+}
+
+type jwkSet struct {
+	Keys []jwk `json:"keys"`
+}
+
+func b64url(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func b64urlDecode(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+func bigIntFromB64url(s string) (*big.Int, error) {
+	b, err := b64urlDecode(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// LoadPublicKeyFromJWK parses a single RSA JSON Web Key.
+func LoadPublicKeyFromJWK(jwkBytes []byte) (rsa.PublicKey, error) {
+	var k jwk
+	if err := json.Unmarshal(jwkBytes, &k); err != nil {
+		return rsa.PublicKey{}, fmt.Errorf("gorsa: invalid JWK: %w", err)
+	}
+	return parseJWKPublic(k)
+}
+
+func parseJWKPublic(k jwk) (rsa.PublicKey, error) {
+	if k.Kty != "RSA" {
+		return rsa.PublicKey{}, fmt.Errorf("gorsa: unsupported JWK kty %q", k.Kty)
+	}
+	if k.N == "" {
+		return rsa.PublicKey{}, errors.New(`gorsa: JWK missing required field "n"`)
+	}
+	if k.E == "" {
+		return rsa.PublicKey{}, errors.New(`gorsa: JWK missing required field "e"`)
+	}
+
+	n, err := bigIntFromB64url(k.N)
+	if err != nil {
+		return rsa.PublicKey{}, fmt.Errorf("gorsa: invalid JWK \"n\": %w", err)
+	}
+	e, err := bigIntFromB64url(k.E)
+	if err != nil {
+		return rsa.PublicKey{}, fmt.Errorf("gorsa: invalid JWK \"e\": %w", err)
+	}
+
+	return rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+}
+
+// LoadPrivateKeyFromJWK parses a single RSA private JSON Web Key.
+func LoadPrivateKeyFromJWK(jwkBytes []byte) (rsa.PrivateKey, error) {
+	var k jwk
+	if err := json.Unmarshal(jwkBytes, &k); err != nil {
+		return rsa.PrivateKey{}, fmt.Errorf("gorsa: invalid JWK: %w", err)
+	}
+
+	pub, err := parseJWKPublic(k)
+	if err != nil {
+		return rsa.PrivateKey{}, err
+	}
+	if k.D == "" {
+		return rsa.PrivateKey{}, errors.New(`gorsa: JWK missing required field "d"`)
+	}
+	if k.P == "" {
+		return rsa.PrivateKey{}, errors.New(`gorsa: JWK missing required field "p"`)
+	}
+	if k.Q == "" {
+		return rsa.PrivateKey{}, errors.New(`gorsa: JWK missing required field "q"`)
+	}
+
+	d, err := bigIntFromB64url(k.D)
+	if err != nil {
+		return rsa.PrivateKey{}, fmt.Errorf("gorsa: invalid JWK \"d\": %w", err)
+	}
+	p, err := bigIntFromB64url(k.P)
+	if err != nil {
+		return rsa.PrivateKey{}, fmt.Errorf("gorsa: invalid JWK \"p\": %w", err)
+	}
+	q, err := bigIntFromB64url(k.Q)
+	if err != nil {
+		return rsa.PrivateKey{}, fmt.Errorf("gorsa: invalid JWK \"q\": %w", err)
+	}
+
+	// Deliberately ignore the JWK's dp/dq/qi (if present): they're CRT
+	// speedups, not independent key material, and Validate() never checks
+	// them against d/p/q. Trusting attacker-supplied values here would let a
+	// JWK with mismatched CRT params "validate" cleanly while producing
+	// corrupt signatures - the classic setup for an RSA-CRT fault attack.
+	// Precompute() derives them itself from D/Primes, same as GenerateKeyPair.
+	key := rsa.PrivateKey{
+		PublicKey: pub,
+		D:         d,
+		Primes:    []*big.Int{p, q},
+	}
+	key.Precompute()
+
+	if err := key.Validate(); err != nil {
+		return rsa.PrivateKey{}, fmt.Errorf("gorsa: invalid JWK private key: %w", err)
+	}
+
+	return key, nil
+}
+
+// MarshalPublicKeyJWK encodes pub as an RSA JSON Web Key with the given key ID.
+func MarshalPublicKeyJWK(pub rsa.PublicKey, kid string) ([]byte, error) {
+	k := jwk{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		N:   b64url(pub.N.Bytes()),
+		E:   b64url(big.NewInt(int64(pub.E)).Bytes()),
+	}
+	return json.Marshal(k)
+}
+
+// FetchJWKS retrieves a JWK Set from url and returns its RSA public keys
+// indexed by "kid". Keys with an unsupported kty are skipped rather than
+// failing the whole fetch, since JWK Sets commonly mix key types (e.g. an
+// EC key used for a different purpose).
+func FetchJWKS(url string) (map[string]rsa.PublicKey, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("gorsa: fetching JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gorsa: fetching JWKS: unexpected status %s", resp.Status)
+	}
+
+	var set jwkSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, fmt.Errorf("gorsa: decoding JWKS: %w", err)
+	}
+
+	keys := make(map[string]rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		pub, err := parseJWKPublic(k)
+		if err != nil {
+			return nil, fmt.Errorf("gorsa: parsing JWK %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = pub
+	}
+
+	return keys, nil
+}
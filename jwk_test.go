@@ -0,0 +1,170 @@
+package gorsa
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestPublicKeyJWKRoundTrip(t *testing.T) {
+	key, err := GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwkBytes, err := MarshalPublicKeyJWK(key.PublicKey, "test-kid")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadPublicKeyFromJWK(jwkBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.N.Cmp(key.N) != 0 || got.E != key.E {
+		t.Fatal("public key does not round-trip through JWK")
+	}
+}
+
+func TestPrivateKeyFromJWKRoundTrip(t *testing.T) {
+	key, err := GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k := jwk{
+		Kty: "RSA",
+		N:   b64url(key.N.Bytes()),
+		E:   b64url(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+		D:   b64url(key.D.Bytes()),
+		P:   b64url(key.Primes[0].Bytes()),
+		Q:   b64url(key.Primes[1].Bytes()),
+		Dp:  b64url(key.Precomputed.Dp.Bytes()),
+		Dq:  b64url(key.Precomputed.Dq.Bytes()),
+		Qi:  b64url(key.Precomputed.Qinv.Bytes()),
+	}
+	b, err := json.Marshal(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadPrivateKeyFromJWK(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.D.Cmp(key.D) != 0 {
+		t.Fatal("private key does not round-trip through JWK")
+	}
+
+	msg := []byte("hello world")
+	ct, err := rsa.EncryptPKCS1v15(rand.Reader, &loaded.PublicKey, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pt, err := rsa.DecryptPKCS1v15(rand.Reader, &loaded, ct)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(pt) != string(msg) {
+		t.Fatal("decrypt/encrypt round-trip mismatch")
+	}
+}
+
+func TestLoadPublicKeyFromJWKMissingField(t *testing.T) {
+	_, err := LoadPublicKeyFromJWK([]byte(`{"kty":"RSA","n":"","e":"AQAB"}`))
+	if err == nil {
+		t.Fatal("expected an error for a missing \"n\"")
+	}
+
+	_, err = LoadPublicKeyFromJWK([]byte(`{"kty":"RSA","n":"AQAB","e":""}`))
+	if err == nil {
+		t.Fatal("expected an error for a missing \"e\"")
+	}
+}
+
+func TestLoadPrivateKeyFromJWKMissingField(t *testing.T) {
+	key, err := GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	base := jwk{
+		Kty: "RSA",
+		N:   b64url(key.N.Bytes()),
+		E:   b64url(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+		D:   b64url(key.D.Bytes()),
+		P:   b64url(key.Primes[0].Bytes()),
+		Q:   b64url(key.Primes[1].Bytes()),
+	}
+
+	for _, field := range []string{"d", "p", "q"} {
+		k := base
+		switch field {
+		case "d":
+			k.D = ""
+		case "p":
+			k.P = ""
+		case "q":
+			k.Q = ""
+		}
+
+		b, err := json.Marshal(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadPrivateKeyFromJWK(b); err == nil {
+			t.Fatalf("expected an error for a missing %q", field)
+		}
+	}
+}
+
+// A JWK with correct n/e/d/p/q but dp/dq/qi borrowed from an unrelated key
+// must not silently produce a key that "validates" but fails to decrypt -
+// LoadPrivateKeyFromJWK must derive the CRT params itself instead of
+// trusting them from the (untrusted) JWK.
+func TestPrivateKeyFromJWKIgnoresTamperedCRTParams(t *testing.T) {
+	key, err := GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	k := jwk{
+		Kty: "RSA",
+		N:   b64url(key.N.Bytes()),
+		E:   b64url(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+		D:   b64url(key.D.Bytes()),
+		P:   b64url(key.Primes[0].Bytes()),
+		Q:   b64url(key.Primes[1].Bytes()),
+		Dp:  b64url(other.Precomputed.Dp.Bytes()),
+		Dq:  b64url(other.Precomputed.Dq.Bytes()),
+		Qi:  b64url(other.Precomputed.Qinv.Bytes()),
+	}
+	b, err := json.Marshal(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadPrivateKeyFromJWK(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := []byte("hello world")
+	ct, err := rsa.EncryptPKCS1v15(rand.Reader, &loaded.PublicKey, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pt, err := rsa.DecryptPKCS1v15(rand.Reader, &loaded, ct)
+	if err != nil {
+		t.Fatalf("decrypt failed, tampered dp/dq/qi were not discarded: %v", err)
+	}
+	if string(pt) != string(msg) {
+		t.Fatal("roundtrip mismatch")
+	}
+}
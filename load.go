@@ -8,11 +8,19 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
-	"io/ioutil"
+	"os"
 )
 
-// LoadPrivateKey from a PEM encoded private (or public) key
-func LoadPrivateKey(pembytes []byte, password string) (key rsa.PrivateKey, err error) {
+// LoadPrivateKey from a PEM encoded private key. password may be
+// nil if the key is expected to be unencrypted.
+func LoadPrivateKey(pembytes []byte, password PasswordProvider) (key rsa.PrivateKey, err error) {
+	return loadPrivateKey(pembytes, password, "")
+}
+
+// loadPrivateKey is LoadPrivateKey with a hint (e.g. a filename) to pass
+// through to password.Password, so PasswordProvider implementations like
+// PromptPassword can tell the user which key they're unlocking.
+func loadPrivateKey(pembytes []byte, password PasswordProvider, hint string) (key rsa.PrivateKey, err error) {
 	pembytes = bytes.TrimSpace(pembytes)
 
 	var block *pem.Block
@@ -22,9 +30,15 @@ func LoadPrivateKey(pembytes []byte, password string) (key rsa.PrivateKey, err e
 		return
 	}
 
+	var pw []byte
+	pw, err = passwordBytes(password, hint)
+	if err != nil {
+		return
+	}
+
 	// Often needed for encrypted keys (i.e. SSH keys)
 	if x509.IsEncryptedPEMBlock(block) {
-		block, err = DecryptPEMBlock(block, password)
+		block, err = DecryptPEMBlock(block, string(pw))
 		if err != nil {
 			err = errors.New("Error decrypting PEM block: " + err.Error())
 			return
@@ -36,7 +50,22 @@ func LoadPrivateKey(pembytes []byte, password string) (key rsa.PrivateKey, err e
 	// "BEGIN ENCRYPTED PRIVATE KEY" is encrypted PKCS#8.
 
 	switch block.Type {
-	case "PRIVATE KEY", "ENCRYPTED PRIVATE KEY", "RSA PRIVATE KEY":
+	case "ENCRYPTED PRIVATE KEY":
+		// Modern PKCS#8 encryption lives inside the DER (PBES2), not in a PEM
+		// "DEK-Info" header, so x509.IsEncryptedPEMBlock never catches this case.
+		var generalKey interface{}
+		generalKey, err = ParsePKCS8EncryptedPrivateKey(block.Bytes, pw)
+		if err != nil {
+			return
+		}
+
+		switch k := generalKey.(type) {
+		case *rsa.PrivateKey:
+			key = *k
+		default:
+			err = fmt.Errorf("Unsupported key type %T", generalKey)
+		}
+	case "PRIVATE KEY", "RSA PRIVATE KEY":
 
 		// PEM keys could be PKCS #1-#15 or another type
 		var generalKey interface{}
@@ -64,6 +93,12 @@ func LoadPrivateKey(pembytes []byte, password string) (key rsa.PrivateKey, err e
 		default:
 			err = fmt.Errorf("Unsupported key type %T", generalKey)
 		}
+	case "EC PRIVATE KEY":
+		// SEC1 elliptic curve keys aren't RSA, so they can't satisfy the
+		// rsa.PrivateKey return type here. Use LoadSigner instead.
+		err = errors.New("EC PRIVATE KEY is not an RSA key, use LoadSigner instead")
+	case "DSA PRIVATE KEY":
+		err = errors.New("DSA PRIVATE KEY is not supported")
 	default:
 		err = fmt.Errorf("Unsupported PEM block type %q", block.Type)
 	}
@@ -72,17 +107,27 @@ func LoadPrivateKey(pembytes []byte, password string) (key rsa.PrivateKey, err e
 }
 
 // LoadPrivateKeyFromFile given (expecting PEM format)
-func LoadPrivateKeyFromFile(filename string, password string) (key rsa.PrivateKey, err error) {
-	var b []byte
-	b, err = ioutil.ReadFile(filename)
+func LoadPrivateKeyFromFile(filename string, password PasswordProvider) (key rsa.PrivateKey, err error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return rsa.PrivateKey{}, err
+	}
 
 	b = bytes.TrimSpace(b)
 
-	return LoadPrivateKey(b, password)
+	return loadPrivateKey(b, password, filename)
 }
 
-// LoadPublicKey from a PEM encoded private (or public) key
-func LoadPublicKey(pembytes []byte, password string) (pubkey rsa.PublicKey, err error) {
+// LoadPublicKey from a PEM encoded private (or public) key. password may be
+// nil if the key is expected to be unencrypted.
+func LoadPublicKey(pembytes []byte, password PasswordProvider) (pubkey rsa.PublicKey, err error) {
+	return loadPublicKey(pembytes, password, "")
+}
+
+// loadPublicKey is LoadPublicKey with a hint (e.g. a filename) to pass
+// through to password.Password, so PasswordProvider implementations like
+// PromptPassword can tell the user which key they're unlocking.
+func loadPublicKey(pembytes []byte, password PasswordProvider, hint string) (pubkey rsa.PublicKey, err error) {
 	pembytes = bytes.TrimSpace(pembytes)
 
 	var block *pem.Block
@@ -92,9 +137,15 @@ func LoadPublicKey(pembytes []byte, password string) (pubkey rsa.PublicKey, err
 		return
 	}
 
+	var pw []byte
+	pw, err = passwordBytes(password, hint)
+	if err != nil {
+		return
+	}
+
 	// Often needed for encrypted keys (i.e. SSH keys)
 	if x509.IsEncryptedPEMBlock(block) {
-		block, err = DecryptPEMBlock(block, password)
+		block, err = DecryptPEMBlock(block, string(pw))
 		if err != nil {
 			err = errors.New("Error decrypting PEM block: " + err.Error())
 			return
@@ -106,9 +157,29 @@ func LoadPublicKey(pembytes []byte, password string) (pubkey rsa.PublicKey, err
 	// "BEGIN ENCRYPTED PRIVATE KEY" is encrypted PKCS#8.
 
 	switch block.Type {
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		cert, err = x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return
+		}
+
+		switch k := cert.PublicKey.(type) {
+		case *rsa.PublicKey:
+			pubkey = *k
+		default:
+			err = fmt.Errorf("Unsupported certificate key type %T", cert.PublicKey)
+		}
+	case "RSA PUBLIC KEY":
+		var k *rsa.PublicKey
+		k, err = x509.ParsePKCS1PublicKey(block.Bytes)
+		if err != nil {
+			return
+		}
+		pubkey = *k
 	case "PUBLIC KEY", "PRIVATE KEY",
 		"ENCRYPTED PRIVATE KEY",
-		"RSA PUBLIC KEY", "RSA PRIVATE KEY":
+		"RSA PRIVATE KEY":
 
 		// PEM keys could be PKCS #1-#15, PKIX, elliptic curve, or another type
 		var generalKey interface{}
@@ -152,11 +223,13 @@ func LoadPublicKey(pembytes []byte, password string) (pubkey rsa.PublicKey, err
 }
 
 // LoadPublicKeyFromFile given (expecting PEM format)
-func LoadPublicKeyFromFile(filename string, password string) (pubkey rsa.PublicKey, err error) {
-	var b []byte
-	b, err = ioutil.ReadFile(filename)
+func LoadPublicKeyFromFile(filename string, password PasswordProvider) (pubkey rsa.PublicKey, err error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return rsa.PublicKey{}, err
+	}
 
 	b = bytes.TrimSpace(b)
 
-	return LoadPublicKey(b, password)
+	return loadPublicKey(b, password, filename)
 }
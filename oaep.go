@@ -0,0 +1,101 @@
+package gorsa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+)
+
+// PublicEncryptOAEP encrypts msg with pub using OAEP padding (RFC 8017),
+// the recommended replacement for PKCS#1 v1.5 encryption padding.
+// label is optional associated data that must be repeated
+// (or left nil) on decryption.
+func PublicEncryptOAEP(pub *rsa.PublicKey, msg, label []byte, hash crypto.Hash) ([]byte, error) {
+	if !hash.Available() {
+		return nil, fmt.Errorf("gorsa: hash %v is not available (missing import?)", hash)
+	}
+	return rsa.EncryptOAEP(hash.New(), rand.Reader, pub, msg, label)
+}
+
+// PrivateDecryptOAEP decrypts ciphertext produced by PublicEncryptOAEP. label
+// must match what was passed to PublicEncryptOAEP.
+func PrivateDecryptOAEP(priv *rsa.PrivateKey, ciphertext, label []byte, hash crypto.Hash) ([]byte, error) {
+	if !hash.Available() {
+		return nil, fmt.Errorf("gorsa: hash %v is not available (missing import?)", hash)
+	}
+	return rsa.DecryptOAEP(hash.New(), rand.Reader, priv, ciphertext, label)
+}
+
+// oaepChunkSize returns the maximum plaintext size per RSA-OAEP block for
+// the given key size and hash, per RFC 8017 section 7.1: k - 2*hLen - 2.
+func oaepChunkSize(pub *rsa.PublicKey, hash crypto.Hash) int {
+	k := pub.Size()
+	hLen := hash.Size()
+	return k - 2*hLen - 2
+}
+
+// PublicEncryptOAEPChunked encrypts msg of arbitrary length by splitting it
+// into RSA-OAEP-sized blocks and concatenating the resulting ciphertexts.
+// Use PrivateDecryptOAEPChunked to reverse it. This is the common shape
+// needed when porting from Node/PHP code that used
+// openssl_public_encrypt(..., OPENSSL_PKCS1_OAEP_PADDING) on payloads larger
+// than a single RSA block.
+func PublicEncryptOAEPChunked(pub *rsa.PublicKey, msg, label []byte, hash crypto.Hash) ([]byte, error) {
+	if !hash.Available() {
+		return nil, fmt.Errorf("gorsa: hash %v is not available (missing import?)", hash)
+	}
+
+	chunkSize := oaepChunkSize(pub, hash)
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("gorsa: key too small for OAEP with hash %v", hash)
+	}
+
+	var out bytes.Buffer
+	hasher := hash.New()
+	for len(msg) > 0 {
+		n := chunkSize
+		if n > len(msg) {
+			n = len(msg)
+		}
+
+		hasher.Reset()
+		ciphertext, err := rsa.EncryptOAEP(hasher, rand.Reader, pub, msg[:n], label)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(ciphertext)
+
+		msg = msg[n:]
+	}
+
+	return out.Bytes(), nil
+}
+
+// PrivateDecryptOAEPChunked reverses PublicEncryptOAEPChunked.
+func PrivateDecryptOAEPChunked(priv *rsa.PrivateKey, ciphertext, label []byte, hash crypto.Hash) ([]byte, error) {
+	if !hash.Available() {
+		return nil, fmt.Errorf("gorsa: hash %v is not available (missing import?)", hash)
+	}
+
+	blockSize := priv.Size()
+	if len(ciphertext)%blockSize != 0 {
+		return nil, fmt.Errorf("gorsa: ciphertext length %d is not a multiple of the key size %d", len(ciphertext), blockSize)
+	}
+
+	var out bytes.Buffer
+	hasher := hash.New()
+	for len(ciphertext) > 0 {
+		hasher.Reset()
+		plaintext, err := rsa.DecryptOAEP(hasher, rand.Reader, priv, ciphertext[:blockSize], label)
+		if err != nil {
+			return nil, err
+		}
+		out.Write(plaintext)
+
+		ciphertext = ciphertext[blockSize:]
+	}
+
+	return out.Bytes(), nil
+}
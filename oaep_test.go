@@ -0,0 +1,73 @@
+package gorsa
+
+import (
+	"bytes"
+	"crypto"
+	"testing"
+)
+
+func TestOAEPRoundTrip(t *testing.T) {
+	key, err := GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("hello world")
+	label := []byte("context")
+
+	ct, err := PublicEncryptOAEP(&key.PublicKey, msg, label, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pt, err := PrivateDecryptOAEP(key, ct, label, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(pt, msg) {
+		t.Fatal("decrypted plaintext does not match the original")
+	}
+
+	if _, err := PrivateDecryptOAEP(key, ct, []byte("wrong label"), crypto.SHA256); err == nil {
+		t.Fatal("expected an error decrypting with a mismatched label")
+	}
+}
+
+func TestOAEPChunkedRoundTrip(t *testing.T) {
+	key, err := GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 2048-bit key with SHA-256 gives a chunk size well under 200 bytes, so
+	// this requires multiple OAEP blocks.
+	msg := bytes.Repeat([]byte("0123456789"), 50)
+
+	ct, err := PublicEncryptOAEPChunked(&key.PublicKey, msg, nil, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chunkSize := oaepChunkSize(&key.PublicKey, crypto.SHA256)
+	wantBlocks := (len(msg) + chunkSize - 1) / chunkSize
+	if len(ct) != wantBlocks*key.Size() {
+		t.Fatalf("ciphertext length = %d, want %d", len(ct), wantBlocks*key.Size())
+	}
+
+	pt, err := PrivateDecryptOAEPChunked(key, ct, nil, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(pt, msg) {
+		t.Fatal("decrypted plaintext does not match the original")
+	}
+}
+
+func TestOAEPChunkedRejectsMisalignedCiphertext(t *testing.T) {
+	key, err := GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := PrivateDecryptOAEPChunked(key, []byte("not a multiple of the key size"), nil, crypto.SHA256); err == nil {
+		t.Fatal("expected an error for a ciphertext whose length isn't a multiple of the key size")
+	}
+}
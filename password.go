@@ -0,0 +1,110 @@
+package gorsa
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// PasswordProvider supplies the passphrase for an encrypted private key.
+// hint is whatever context the caller has about which key is being
+// unlocked (e.g. a filename); implementations may ignore it.
+type PasswordProvider interface {
+	Password(hint string) ([]byte, error)
+}
+
+// passwordProviderFunc adapts a function to a PasswordProvider.
+type passwordProviderFunc func(hint string) ([]byte, error)
+
+func (f passwordProviderFunc) Password(hint string) ([]byte, error) { return f(hint) }
+
+// noPassword is used where a nil PasswordProvider is passed to a loader,
+// meaning the key is expected to be unencrypted.
+var noPassword PasswordProvider = passwordProviderFunc(func(string) ([]byte, error) {
+	return nil, nil
+})
+
+// passwordBytes resolves provider to a password, treating a nil provider as
+// "no password" rather than an error.
+func passwordBytes(provider PasswordProvider, hint string) ([]byte, error) {
+	if provider == nil {
+		provider = noPassword
+	}
+	return provider.Password(hint)
+}
+
+// StaticPassword returns a PasswordProvider for a password already known by
+// the caller (e.g. loaded from a secret manager).
+func StaticPassword(password []byte) PasswordProvider {
+	return passwordProviderFunc(func(string) ([]byte, error) {
+		return password, nil
+	})
+}
+
+// EnvPassword returns a PasswordProvider that reads the password from the
+// named environment variable. A variable that is set but empty yields an
+// empty password; a missing (unset) variable is an error, since that usually
+// indicates a misconfigured caller rather than an intentionally blank password.
+func EnvPassword(name string) PasswordProvider {
+	return passwordProviderFunc(func(string) ([]byte, error) {
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return nil, fmt.Errorf("gorsa: environment variable %q is not set", name)
+		}
+		return []byte(v), nil
+	})
+}
+
+// PromptPassword returns a PasswordProvider that interactively reads the
+// password from the controlling terminal (/dev/tty), printing prompt first.
+// When the loader passes a non-empty hint, the prompt is printed with its
+// trailing ": " removed and the hint appended in parentheses, falling back
+// to "Password" if prompt is empty.
+// It fails in non-interactive contexts (e.g. no controlling TTY), which
+// callers should handle by falling back to another PasswordProvider.
+func PromptPassword(prompt string) PasswordProvider {
+	return passwordProviderFunc(func(hint string) ([]byte, error) {
+		tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+		if err != nil {
+			return nil, fmt.Errorf("gorsa: opening /dev/tty: %w", err)
+		}
+		defer tty.Close()
+
+		displayPrompt := prompt
+		if hint != "" {
+			base := strings.TrimRight(prompt, ": ")
+			if base == "" {
+				base = "Password"
+			}
+			displayPrompt = fmt.Sprintf("%s (%s): ", base, hint)
+		}
+		if displayPrompt != "" {
+			if _, err := fmt.Fprint(tty, displayPrompt); err != nil {
+				return nil, err
+			}
+		}
+
+		password, err := term.ReadPassword(int(tty.Fd()))
+		fmt.Fprintln(tty)
+		if err != nil {
+			return nil, fmt.Errorf("gorsa: reading password: %w", err)
+		}
+
+		return password, nil
+	})
+}
+
+// FilePassword returns a PasswordProvider that reads the password from path,
+// trimming any trailing "\r" and "\n" characters (the common shape of a
+// password file written by `echo mypassword > file`).
+func FilePassword(path string) PasswordProvider {
+	return passwordProviderFunc(func(string) ([]byte, error) {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("gorsa: reading password file %s: %w", path, err)
+		}
+		return []byte(strings.TrimRight(string(b), "\r\n")), nil
+	})
+}
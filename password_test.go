@@ -0,0 +1,74 @@
+package gorsa
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestStaticPassword(t *testing.T) {
+	p := StaticPassword([]byte("s3cret"))
+	got, err := p.Password("some-hint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, []byte("s3cret")) {
+		t.Fatalf("got %q, want %q", got, "s3cret")
+	}
+}
+
+func TestEnvPassword(t *testing.T) {
+	t.Setenv("GORSA_TEST_PASSWORD", "env-s3cret")
+
+	p := EnvPassword("GORSA_TEST_PASSWORD")
+	got, err := p.Password("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, []byte("env-s3cret")) {
+		t.Fatalf("got %q, want %q", got, "env-s3cret")
+	}
+}
+
+func TestEnvPasswordMissing(t *testing.T) {
+	os.Unsetenv("GORSA_TEST_PASSWORD_UNSET")
+
+	p := EnvPassword("GORSA_TEST_PASSWORD_UNSET")
+	if _, err := p.Password(""); err == nil {
+		t.Fatal("expected an error for an unset environment variable")
+	}
+}
+
+func TestFilePassword(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password.txt")
+	if err := os.WriteFile(path, []byte("file-s3cret\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	p := FilePassword(path)
+	got, err := p.Password("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, []byte("file-s3cret")) {
+		t.Fatalf("got %q, want %q", got, "file-s3cret")
+	}
+}
+
+func TestFilePasswordMissing(t *testing.T) {
+	p := FilePassword(filepath.Join(t.TempDir(), "does-not-exist.txt"))
+	if _, err := p.Password(""); err == nil {
+		t.Fatal("expected an error reading a missing password file")
+	}
+}
+
+func TestPasswordBytesNilProvider(t *testing.T) {
+	got, err := passwordBytes(nil, "hint")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != nil {
+		t.Fatalf("got %q, want no password", got)
+	}
+}
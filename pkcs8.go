@@ -0,0 +1,188 @@
+package gorsa
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// OIDs for the PBES2 (PKCS#5 v2.0) structures used to encrypt PKCS#8 private
+// keys. The stdlib's x509 package can read plain PKCS#8 but has no support
+// for producing or parsing the encrypted form, so we implement the small
+// slice of RFC 8018 needed for AES-256-CBC + PBKDF2-SHA256 ourselves.
+var (
+	oidPBES2      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+	oidHMACSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidAES256CBC  = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+const (
+	pbkdf2SaltLen = 16
+	pbkdf2Iter    = 310000 // OWASP 2023 minimum for PBKDF2-HMAC-SHA256
+)
+
+type encryptedPrivateKeyInfo struct {
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// MarshalPKCS8EncryptedPrivateKey encodes key as a PKCS#8 EncryptedPrivateKeyInfo
+// DER structure using PBES2 with PBKDF2-SHA256 key derivation and AES-256-CBC
+// encryption, per RFC 8018. This is the modern replacement for the deprecated
+// PBES1 scheme produced by x509.EncryptPEMBlock.
+func MarshalPKCS8EncryptedPrivateKey(key *rsa.PrivateKey, password []byte) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("gorsa: marshaling PKCS#8 private key: %w", err)
+	}
+
+	salt := make([]byte, pbkdf2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("gorsa: generating PBKDF2 salt: %w", err)
+	}
+
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(iv); err != nil {
+		return nil, fmt.Errorf("gorsa: generating AES IV: %w", err)
+	}
+
+	dk := pbkdf2.Key(password, salt, pbkdf2Iter, 32, sha256.New)
+
+	block, err := aes.NewCipher(dk)
+	if err != nil {
+		return nil, fmt.Errorf("gorsa: creating AES cipher: %w", err)
+	}
+
+	padded := pkcs7Pad(der, aes.BlockSize)
+	ciphertext := make([]byte, len(padded))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, padded)
+
+	kdfParams, err := asn1.Marshal(pbkdf2Params{
+		Salt:           salt,
+		IterationCount: pbkdf2Iter,
+		PRF:            pkix.AlgorithmIdentifier{Algorithm: oidHMACSHA256},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	encParams, err := asn1.Marshal(iv)
+	if err != nil {
+		return nil, err
+	}
+
+	schemeParams, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidPBKDF2, Parameters: asn1.RawValue{FullBytes: kdfParams}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: encParams}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(encryptedPrivateKeyInfo{
+		Algo:       pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: schemeParams}},
+		PrivateKey: ciphertext,
+	})
+}
+
+// ParsePKCS8EncryptedPrivateKey decrypts a PBES2/PBKDF2/AES-256-CBC encoded
+// EncryptedPrivateKeyInfo DER structure (as produced by
+// MarshalPKCS8EncryptedPrivateKey) and parses the resulting PKCS#8 key.
+func ParsePKCS8EncryptedPrivateKey(der, password []byte) (interface{}, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, fmt.Errorf("gorsa: invalid EncryptedPrivateKeyInfo: %w", err)
+	}
+
+	if !info.Algo.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("gorsa: unsupported PKCS#8 encryption algorithm %s", info.Algo.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algo.Parameters.FullBytes, &params); err != nil {
+		return nil, fmt.Errorf("gorsa: invalid PBES2 params: %w", err)
+	}
+
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("gorsa: unsupported key derivation function %s", params.KeyDerivationFunc.Algorithm)
+	}
+	if !params.EncryptionScheme.Algorithm.Equal(oidAES256CBC) {
+		return nil, fmt.Errorf("gorsa: unsupported encryption scheme %s", params.EncryptionScheme.Algorithm)
+	}
+
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, fmt.Errorf("gorsa: invalid PBKDF2 params: %w", err)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, fmt.Errorf("gorsa: invalid AES-CBC IV: %w", err)
+	}
+
+	dk := pbkdf2.Key(password, kdf.Salt, kdf.IterationCount, 32, sha256.New)
+
+	block, err := aes.NewCipher(dk)
+	if err != nil {
+		return nil, fmt.Errorf("gorsa: creating AES cipher: %w", err)
+	}
+	if len(info.PrivateKey)%aes.BlockSize != 0 {
+		return nil, errors.New("gorsa: ciphertext is not a multiple of the block size")
+	}
+
+	plaintext := make([]byte, len(info.PrivateKey))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, info.PrivateKey)
+
+	plaintext, err = pkcs7Unpad(plaintext, aes.BlockSize)
+	if err != nil {
+		return nil, fmt.Errorf("gorsa: incorrect password or corrupt key: %w", err)
+	}
+
+	return x509.ParsePKCS8PrivateKey(plaintext)
+}
+
+func pkcs7Pad(data []byte, blockSize int) []byte {
+	padLen := blockSize - len(data)%blockSize
+	padding := make([]byte, padLen)
+	for i := range padding {
+		padding[i] = byte(padLen)
+	}
+	return append(data, padding...)
+}
+
+func pkcs7Unpad(data []byte, blockSize int) ([]byte, error) {
+	if len(data) == 0 || len(data)%blockSize != 0 {
+		return nil, errors.New("invalid padded data")
+	}
+	padLen := int(data[len(data)-1])
+	if padLen == 0 || padLen > blockSize || padLen > len(data) {
+		return nil, errors.New("invalid padding")
+	}
+	for _, b := range data[len(data)-padLen:] {
+		if int(b) != padLen {
+			return nil, errors.New("invalid padding")
+		}
+	}
+	return data[:len(data)-padLen], nil
+}
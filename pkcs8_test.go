@@ -0,0 +1,47 @@
+package gorsa
+
+import (
+	"crypto/rsa"
+	"testing"
+)
+
+func TestPKCS8EncryptedPrivateKeyRoundTrip(t *testing.T) {
+	key, err := GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := MarshalPKCS8EncryptedPrivateKey(key, []byte("correct horse battery staple"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generalKey, err := ParsePKCS8EncryptedPrivateKey(der, []byte("correct horse battery staple"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, ok := generalKey.(*rsa.PrivateKey)
+	if !ok {
+		t.Fatalf("expected *rsa.PrivateKey, got %T", generalKey)
+	}
+	if got.N.Cmp(key.N) != 0 || got.D.Cmp(key.D) != 0 {
+		t.Fatal("decrypted key does not match the original")
+	}
+}
+
+func TestPKCS8EncryptedPrivateKeyWrongPassword(t *testing.T) {
+	key, err := GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := MarshalPKCS8EncryptedPrivateKey(key, []byte("correct horse battery staple"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParsePKCS8EncryptedPrivateKey(der, []byte("wrong password")); err == nil {
+		t.Fatal("expected an error decrypting with the wrong password")
+	}
+}
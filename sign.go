@@ -0,0 +1,179 @@
+package gorsa
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	// Hash implementations must be registered for crypto.Hash.New to work.
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// SignScheme selects the RSA signature padding used by Sign/Verify.
+type SignScheme int
+
+const (
+	// SchemePKCS1v15 signs using RSASSA-PKCS1-v1_5 (rsa.SignPKCS1v15).
+	SchemePKCS1v15 SignScheme = iota
+	// SchemePSS signs using RSASSA-PSS (rsa.SignPSS).
+	SchemePSS
+)
+
+// SignOptions configures Sign and Verify.
+type SignOptions struct {
+	Scheme SignScheme
+	Hash   crypto.Hash
+
+	// SaltLength is only used by SchemePSS. Nil means
+	// rsa.PSSSaltLengthEqualsHash, since that's what JWA (RFC 7518) requires
+	// for PS256/384/512 and it's the only sensible default for a zero-value
+	// SignOptions{}. A plain int field can't distinguish "unset" from the
+	// explicit rsa.PSSSaltLengthAuto (which is itself 0), so use
+	// PSSSaltLength to build a non-nil value, e.g.
+	// PSSSaltLength(rsa.PSSSaltLengthAuto) to opt into the stdlib default.
+	SaltLength *int
+}
+
+// PSSSaltLength returns a pointer to n for use as SignOptions.SaltLength.
+func PSSSaltLength(n int) *int {
+	return &n
+}
+
+// pssSaltLength returns opts.SaltLength, substituting
+// rsa.PSSSaltLengthEqualsHash when it's unset (nil).
+func pssSaltLength(opts SignOptions) int {
+	if opts.SaltLength == nil {
+		return rsa.PSSSaltLengthEqualsHash
+	}
+	return *opts.SaltLength
+}
+
+// Sign hashes msg and produces a signature over it using priv, per opts.
+func Sign(priv *rsa.PrivateKey, msg []byte, opts SignOptions) ([]byte, error) {
+	if !opts.Hash.Available() {
+		return nil, fmt.Errorf("gorsa: hash %v is not available (missing import?)", opts.Hash)
+	}
+
+	h := opts.Hash.New()
+	h.Write(msg)
+	digest := h.Sum(nil)
+
+	switch opts.Scheme {
+	case SchemePKCS1v15:
+		return rsa.SignPKCS1v15(rand.Reader, priv, opts.Hash, digest)
+	case SchemePSS:
+		return rsa.SignPSS(rand.Reader, priv, opts.Hash, digest, &rsa.PSSOptions{
+			SaltLength: pssSaltLength(opts),
+			Hash:       opts.Hash,
+		})
+	default:
+		return nil, fmt.Errorf("gorsa: unsupported SignScheme %d", opts.Scheme)
+	}
+}
+
+// Verify reports whether sig is a valid signature of msg under pub, per opts.
+func Verify(pub *rsa.PublicKey, msg, sig []byte, opts SignOptions) error {
+	if !opts.Hash.Available() {
+		return fmt.Errorf("gorsa: hash %v is not available (missing import?)", opts.Hash)
+	}
+
+	h := opts.Hash.New()
+	h.Write(msg)
+	digest := h.Sum(nil)
+
+	switch opts.Scheme {
+	case SchemePKCS1v15:
+		return rsa.VerifyPKCS1v15(pub, opts.Hash, digest, sig)
+	case SchemePSS:
+		return rsa.VerifyPSS(pub, opts.Hash, digest, sig, &rsa.PSSOptions{
+			SaltLength: pssSaltLength(opts),
+			Hash:       opts.Hash,
+		})
+	default:
+		return fmt.Errorf("gorsa: unsupported SignScheme %d", opts.Scheme)
+	}
+}
+
+// jwtAlgorithm maps a SignOptions to the JWA "alg" header value.
+func jwtAlgorithm(opts SignOptions) (string, error) {
+	switch opts.Scheme {
+	case SchemePKCS1v15:
+		switch opts.Hash {
+		case crypto.SHA256:
+			return "RS256", nil
+		case crypto.SHA384:
+			return "RS384", nil
+		case crypto.SHA512:
+			return "RS512", nil
+		}
+	case SchemePSS:
+		switch opts.Hash {
+		case crypto.SHA256:
+			return "PS256", nil
+		case crypto.SHA384:
+			return "PS384", nil
+		case crypto.SHA512:
+			return "PS512", nil
+		}
+	}
+	return "", fmt.Errorf("gorsa: no JWA alg for scheme %d / hash %v", opts.Scheme, opts.Hash)
+}
+
+// SignJWT produces a compact-serialized JWT (header.payload.signature, all
+// base64url, no padding) signing with priv using the scheme/hash in opts
+// (RS256/RS384/RS512 for SchemePKCS1v15, PS256/PS384/PS512 for SchemePSS).
+// header and payload are marshaled as JSON; header's "alg" field is set or
+// overwritten to match opts.
+func SignJWT(priv *rsa.PrivateKey, header, payload interface{}, opts SignOptions) (string, error) {
+	alg, err := jwtAlgorithm(opts)
+	if err != nil {
+		return "", err
+	}
+
+	headerMap, err := toJSONMap(header)
+	if err != nil {
+		return "", fmt.Errorf("gorsa: invalid JWT header: %w", err)
+	}
+	headerMap["alg"] = alg
+
+	headerJSON, err := json.Marshal(headerMap)
+	if err != nil {
+		return "", err
+	}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." +
+		base64.RawURLEncoding.EncodeToString(payloadJSON)
+
+	sig, err := Sign(priv, []byte(signingInput), opts)
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+func toJSONMap(v interface{}) (map[string]interface{}, error) {
+	if v == nil {
+		return map[string]interface{}{}, nil
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, errors.New("header must marshal to a JSON object")
+	}
+	return m, nil
+}
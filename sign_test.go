@@ -0,0 +1,95 @@
+package gorsa
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+func TestSignVerifyRoundTrip(t *testing.T) {
+	key, err := GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("hello world")
+
+	schemes := []SignScheme{SchemePKCS1v15, SchemePSS}
+	hashes := []crypto.Hash{crypto.SHA256, crypto.SHA384, crypto.SHA512}
+
+	for _, scheme := range schemes {
+		for _, hash := range hashes {
+			opts := SignOptions{Scheme: scheme, Hash: hash}
+
+			sig, err := Sign(key, msg, opts)
+			if err != nil {
+				t.Fatalf("scheme %d / hash %v: Sign: %v", scheme, hash, err)
+			}
+			if err := Verify(&key.PublicKey, msg, sig, opts); err != nil {
+				t.Fatalf("scheme %d / hash %v: Verify: %v", scheme, hash, err)
+			}
+
+			if err := Verify(&key.PublicKey, []byte("tampered"), sig, opts); err == nil {
+				t.Fatalf("scheme %d / hash %v: Verify succeeded on a tampered message", scheme, hash)
+			}
+		}
+	}
+}
+
+func TestPSSSaltLengthAuto(t *testing.T) {
+	key, err := GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("hello world")
+
+	opts := SignOptions{
+		Scheme:     SchemePSS,
+		Hash:       crypto.SHA256,
+		SaltLength: PSSSaltLength(rsa.PSSSaltLengthAuto),
+	}
+
+	sig, err := Sign(key, msg, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Verify(&key.PublicKey, msg, sig, opts); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSignJWTRoundTrip(t *testing.T) {
+	key, err := GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header := map[string]string{"typ": "JWT"}
+	payload := map[string]string{"sub": "test"}
+
+	for _, opts := range []SignOptions{
+		{Scheme: SchemePKCS1v15, Hash: crypto.SHA256},
+		{Scheme: SchemePSS, Hash: crypto.SHA256},
+	} {
+		token, err := SignJWT(key, header, payload, opts)
+		if err != nil {
+			t.Fatalf("scheme %d: SignJWT: %v", opts.Scheme, err)
+		}
+		if token == "" {
+			t.Fatalf("scheme %d: SignJWT returned an empty token", opts.Scheme)
+		}
+
+		parts := strings.Split(token, ".")
+		if len(parts) != 3 {
+			t.Fatalf("scheme %d: token has %d parts, want 3", opts.Scheme, len(parts))
+		}
+		sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil {
+			t.Fatalf("scheme %d: decoding signature: %v", opts.Scheme, err)
+		}
+		if err := Verify(&key.PublicKey, []byte(parts[0]+"."+parts[1]), sig, opts); err != nil {
+			t.Fatalf("scheme %d: signature does not verify: %v", opts.Scheme, err)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package gorsa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// Signer wraps one of the private key types gorsa can load: *rsa.PrivateKey,
+// *ecdsa.PrivateKey, or ed25519.PrivateKey. It lets callers sign messages without
+// knowing the concrete key algorithm in advance.
+type Signer interface {
+	// Sign produces a signature over msg. For RSA keys hash must be a valid
+	// crypto.Hash registered with a Hash function (e.g. crypto.SHA256) and msg
+	// must already be the digest; Ed25519 ignores hash and signs msg directly.
+	Sign(msg []byte, hash crypto.Hash) ([]byte, error)
+
+	// Public returns the Verifier half of this key pair.
+	Public() Verifier
+}
+
+// Verifier wraps one of the public key types that correspond to a Signer:
+// *rsa.PublicKey, *ecdsa.PublicKey, or ed25519.PublicKey.
+type Verifier interface {
+	// Verify reports whether sig is a valid signature of msg under this key.
+	Verify(msg, sig []byte, hash crypto.Hash) error
+}
+
+type rsaSigner struct{ key *rsa.PrivateKey }
+type ecdsaSigner struct{ key *ecdsa.PrivateKey }
+type ed25519Signer struct{ key ed25519.PrivateKey }
+
+type rsaVerifier struct{ key *rsa.PublicKey }
+type ecdsaVerifier struct{ key *ecdsa.PublicKey }
+type ed25519Verifier struct{ key ed25519.PublicKey }
+
+func (s rsaSigner) Sign(msg []byte, hash crypto.Hash) ([]byte, error) {
+	return rsa.SignPKCS1v15(rand.Reader, s.key, hash, msg)
+}
+
+func (s rsaSigner) Public() Verifier {
+	return rsaVerifier{&s.key.PublicKey}
+}
+
+func (v rsaVerifier) Verify(msg, sig []byte, hash crypto.Hash) error {
+	return rsa.VerifyPKCS1v15(v.key, hash, msg, sig)
+}
+
+func (s ecdsaSigner) Sign(msg []byte, hash crypto.Hash) ([]byte, error) {
+	return ecdsa.SignASN1(rand.Reader, s.key, msg)
+}
+
+func (s ecdsaSigner) Public() Verifier {
+	return ecdsaVerifier{&s.key.PublicKey}
+}
+
+func (v ecdsaVerifier) Verify(msg, sig []byte, hash crypto.Hash) error {
+	if !ecdsa.VerifyASN1(v.key, msg, sig) {
+		return errors.New("gorsa: invalid ECDSA signature")
+	}
+	return nil
+}
+
+func (s ed25519Signer) Sign(msg []byte, hash crypto.Hash) ([]byte, error) {
+	return ed25519.Sign(s.key, msg), nil
+}
+
+func (s ed25519Signer) Public() Verifier {
+	return ed25519Verifier{s.key.Public().(ed25519.PublicKey)}
+}
+
+func (v ed25519Verifier) Verify(msg, sig []byte, hash crypto.Hash) error {
+	if !ed25519.Verify(v.key, msg, sig) {
+		return errors.New("gorsa: invalid Ed25519 signature")
+	}
+	return nil
+}
+
+// LoadSigner from a PEM encoded private key, dispatching on the concrete key
+// type instead of assuming RSA. Supported key encodings are PKCS#8
+// ("PRIVATE KEY"/"ENCRYPTED PRIVATE KEY"), PKCS#1 ("RSA PRIVATE KEY"), and
+// SEC1 ("EC PRIVATE KEY"); unlike LoadPrivateKey, SEC1 keys are accepted.
+func LoadSigner(pembytes []byte, password PasswordProvider) (Signer, error) {
+	pembytes = bytes.TrimSpace(pembytes)
+
+	block, _ := pem.Decode(pembytes)
+	if block == nil {
+		return nil, errors.New("Invalid PEM key file")
+	}
+
+	pw, err := passwordBytes(password, "")
+	if err != nil {
+		return nil, err
+	}
+
+	if block.Type == "ENCRYPTED PRIVATE KEY" {
+		generalKey, err := ParsePKCS8EncryptedPrivateKey(block.Bytes, pw)
+		if err != nil {
+			return nil, err
+		}
+		return signerFromKey(generalKey)
+	}
+
+	if x509.IsEncryptedPEMBlock(block) {
+		block, err = DecryptPEMBlock(block, string(pw))
+		if err != nil {
+			return nil, errors.New("Error decrypting PEM block: " + err.Error())
+		}
+	}
+
+	generalKey, err := parseAnyPrivateKey(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return signerFromKey(generalKey)
+}
+
+func signerFromKey(generalKey interface{}) (Signer, error) {
+	switch k := generalKey.(type) {
+	case *rsa.PrivateKey:
+		return rsaSigner{k}, nil
+	case *ecdsa.PrivateKey:
+		return ecdsaSigner{k}, nil
+	case ed25519.PrivateKey:
+		return ed25519Signer{k}, nil
+	default:
+		return nil, fmt.Errorf("Unsupported key type %T", generalKey)
+	}
+}
+
+// LoadVerifier from a PEM encoded public key (or certificate), dispatching on
+// the concrete key type instead of assuming RSA.
+func LoadVerifier(pembytes []byte) (Verifier, error) {
+	pembytes = bytes.TrimSpace(pembytes)
+
+	block, _ := pem.Decode(pembytes)
+	if block == nil {
+		return nil, errors.New("Invalid PEM key file")
+	}
+
+	var generalKey interface{}
+	if block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		generalKey = cert.PublicKey
+	} else {
+		var err error
+		generalKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			generalKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("Unsupported PEM block type %q", block.Type)
+			}
+		}
+	}
+
+	switch k := generalKey.(type) {
+	case *rsa.PublicKey:
+		return rsaVerifier{k}, nil
+	case *ecdsa.PublicKey:
+		return ecdsaVerifier{k}, nil
+	case ed25519.PublicKey:
+		return ed25519Verifier{k}, nil
+	default:
+		return nil, fmt.Errorf("Unsupported key type %T", generalKey)
+	}
+}
+
+// parseAnyPrivateKey tries, in order, PKCS#8, PKCS#1, and SEC1 (EC) parsing
+// of a decoded (and already-decrypted) PEM block's bytes.
+func parseAnyPrivateKey(block *pem.Block) (interface{}, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("Unsupported PEM block type %q", block.Type)
+}
@@ -0,0 +1,133 @@
+package gorsa
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestSignerVerifierRSA(t *testing.T) {
+	key, err := GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	signer, err := LoadSigner(privPEM, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+
+	verifier, err := LoadVerifier(pubPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := []byte("hello world")
+	h := crypto.SHA256.New()
+	h.Write(msg)
+	digest := h.Sum(nil)
+
+	sig, err := signer.Sign(digest, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifier.Verify(digest, sig, crypto.SHA256); err != nil {
+		t.Fatal(err)
+	}
+	if err := signer.Public().Verify(digest, sig, crypto.SHA256); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSignerVerifierECDSA(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+
+	signer, err := LoadSigner(privPEM, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+
+	verifier, err := LoadVerifier(pubPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := []byte("hello world")
+	sig, err := signer.Sign(msg, crypto.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifier.Verify(msg, sig, crypto.SHA256); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSignerVerifierEd25519(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+	signer, err := LoadSigner(privPEM, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubDER, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER})
+
+	verifier, err := LoadVerifier(pubPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := []byte("hello world")
+	sig, err := signer.Sign(msg, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verifier.Verify(msg, sig, 0); err != nil {
+		t.Fatal(err)
+	}
+}